@@ -0,0 +1,337 @@
+// Package auth provides user registration, login, and JWT-based session
+// verification for the book API. It owns the users table and the
+// Authorization header/cookie checks that gate mutating requests.
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/Murluckk/vera/httpx"
+	"github.com/Murluckk/vera/validate"
+)
+
+// defaultMaxBodyBytes bounds a request body read by decodeJSON, unless
+// NewService is given a different limit.
+const defaultMaxBodyBytes = 1 << 20 // 1 MiB
+
+// Username and password are each constrained to this many runes.
+const (
+	minUsernameLen = 1
+	maxUsernameLen = 64
+	minPasswordLen = 8
+	maxPasswordLen = 255
+)
+
+// Role identifies what a user is allowed to do. Roles are ordered:
+// reader < editor < admin, and a higher role satisfies any requirement a
+// lower one does.
+type Role string
+
+const (
+	RoleReader Role = "reader"
+	RoleEditor Role = "editor"
+	RoleAdmin  Role = "admin"
+)
+
+func (r Role) rank() int {
+	switch r {
+	case RoleReader:
+		return 1
+	case RoleEditor:
+		return 2
+	case RoleAdmin:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// Allows reports whether r satisfies the given requirement.
+func (r Role) Allows(required Role) bool {
+	return r.rank() >= required.rank()
+}
+
+func (r Role) valid() bool {
+	return r.rank() > 0
+}
+
+// RoleForMethod returns the minimum role required to perform an HTTP
+// method against the books resource: readers may GET, editors may
+// POST/PUT, and only admins may DELETE.
+func RoleForMethod(method string) Role {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return RoleReader
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		return RoleEditor
+	case http.MethodDelete:
+		return RoleAdmin
+	default:
+		return RoleAdmin
+	}
+}
+
+// User is a registered account.
+type User struct {
+	ID           int64     `json:"id"`
+	Username     string    `json:"username"`
+	PasswordHash string    `json:"-"`
+	Role         Role      `json:"role"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Claims are the custom fields carried in a session token.
+type Claims struct {
+	UserID   int64  `json:"user_id"`
+	Username string `json:"username"`
+	Role     Role   `json:"role"`
+	jwt.RegisteredClaims
+}
+
+type contextKey int
+
+const claimsContextKey contextKey = iota
+
+// FromContext returns the claims attached to the request by
+// VerifySessionToken, if any.
+func FromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsContextKey).(*Claims)
+	return claims, ok
+}
+
+type credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// Validate checks username/password against minimum/maximum length,
+// UTF-8, and control-character rules, satisfying validate.Validator.
+// Username is expected to already be trimmed by the caller.
+func (c credentials) Validate() validate.Errors {
+	errs := validate.Errors{}
+	validate.String(errs, "username", c.Username, minUsernameLen, maxUsernameLen)
+	validate.String(errs, "password", c.Password, minPasswordLen, maxPasswordLen)
+	return errs
+}
+
+// Service issues and verifies session tokens and backs the
+// register/login endpoints.
+type Service struct {
+	db           *sql.DB
+	secret       []byte
+	ttl          time.Duration
+	logger       *slog.Logger
+	maxBodyBytes int64
+}
+
+// NewService constructs a Service. secret must be non-empty; ttl
+// controls how long issued tokens remain valid. logger is used to
+// report internal errors; if nil, the default slog logger is used.
+// maxBodyBytes caps a register/login request body; if <= 0,
+// defaultMaxBodyBytes is used.
+func NewService(db *sql.DB, secret string, ttl time.Duration, logger *slog.Logger, maxBodyBytes int64) (*Service, error) {
+	if secret == "" {
+		return nil, errors.New("auth: secret must not be empty")
+	}
+	if ttl == 0 {
+		ttl = 24 * time.Hour
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if maxBodyBytes <= 0 {
+		maxBodyBytes = defaultMaxBodyBytes
+	}
+	return &Service{db: db, secret: []byte(secret), ttl: ttl, logger: logger, maxBodyBytes: maxBodyBytes}, nil
+}
+
+// Register handles POST /auth/register.
+func (s *Service) Register(w http.ResponseWriter, r *http.Request) {
+	var creds credentials
+	if !s.decodeJSON(w, r, &creds) {
+		return
+	}
+	creds.Username = strings.TrimSpace(creds.Username)
+
+	if errs := creds.Validate(); len(errs) > 0 {
+		respondValidationErrors(w, errs)
+		return
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(creds.Password), bcrypt.DefaultCost)
+	if err != nil {
+		s.respondInternalError(w, r, err)
+		return
+	}
+
+	var u User
+	err = s.db.QueryRow(
+		`INSERT INTO users (username, password_hash, role) VALUES ($1, $2, $3)
+		 RETURNING id, username, role, created_at`,
+		creds.Username, string(hash), RoleReader,
+	).Scan(&u.ID, &u.Username, &u.Role, &u.CreatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			respondError(w, http.StatusConflict, "username already taken")
+			return
+		}
+		s.respondInternalError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, u)
+}
+
+// Login handles POST /auth/login and returns a signed JWT on success.
+func (s *Service) Login(w http.ResponseWriter, r *http.Request) {
+	var creds credentials
+	if !s.decodeJSON(w, r, &creds) {
+		return
+	}
+	creds.Username = strings.TrimSpace(creds.Username)
+
+	if errs := creds.Validate(); len(errs) > 0 {
+		respondValidationErrors(w, errs)
+		return
+	}
+
+	var u User
+	err := s.db.QueryRow(
+		`SELECT id, username, password_hash, role, created_at FROM users WHERE username = $1`,
+		creds.Username,
+	).Scan(&u.ID, &u.Username, &u.PasswordHash, &u.Role, &u.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		respondError(w, http.StatusUnauthorized, "invalid username or password")
+		return
+	}
+	if err != nil {
+		s.respondInternalError(w, r, err)
+		return
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(creds.Password)); err != nil {
+		respondError(w, http.StatusUnauthorized, "invalid username or password")
+		return
+	}
+
+	token, err := s.issueToken(u)
+	if err != nil {
+		s.respondInternalError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"token": token})
+}
+
+func (s *Service) issueToken(u User) (string, error) {
+	now := time.Now()
+	claims := Claims{
+		UserID:   u.ID,
+		Username: u.Username,
+		Role:     u.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.ttl)),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(s.secret)
+}
+
+func (s *Service) parseToken(tokenStr string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenStr, claims, func(t *jwt.Token) (any, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+		return s.secret, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid || !claims.Role.valid() {
+		return nil, errors.New("invalid token")
+	}
+	return claims, nil
+}
+
+// VerifySessionToken requires a valid Authorization: Bearer <token>
+// header (or a "session" cookie carrying the same token), and rejects
+// the request if the caller's role doesn't satisfy RoleForMethod(method).
+// It is a standard net/http middleware so it composes with chi route groups.
+func (s *Service) VerifySessionToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenStr := bearerToken(r)
+		if tokenStr == "" {
+			respondError(w, http.StatusUnauthorized, "missing bearer token")
+			return
+		}
+
+		claims, err := s.parseToken(tokenStr)
+		if err != nil {
+			respondError(w, http.StatusUnauthorized, "invalid or expired token")
+			return
+		}
+
+		if required := RoleForMethod(r.Method); !claims.Role.Allows(required) {
+			respondError(w, http.StatusForbidden, "insufficient role")
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), claimsContextKey, claims)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func bearerToken(r *http.Request) string {
+	if h := r.Header.Get("Authorization"); h != "" {
+		const prefix = "Bearer "
+		if strings.HasPrefix(h, prefix) {
+			return strings.TrimSpace(strings.TrimPrefix(h, prefix))
+		}
+	}
+	if c, err := r.Cookie("session"); err == nil {
+		return c.Value
+	}
+	return ""
+}
+
+func isUniqueViolation(err error) bool {
+	return strings.Contains(err.Error(), "duplicate key value violates unique constraint")
+}
+
+func respondJSON(w http.ResponseWriter, status int, v any) {
+	httpx.RespondJSON(w, status, v)
+}
+
+func respondError(w http.ResponseWriter, status int, msg string) {
+	httpx.RespondError(w, status, msg)
+}
+
+// respondValidationErrors returns a 422 with one message per invalid
+// field, e.g. {"errors":{"password":"must be 8-255 chars"}}.
+func respondValidationErrors(w http.ResponseWriter, errs validate.Errors) {
+	httpx.RespondValidationErrors(w, errs)
+}
+
+// decodeJSON enforces s.maxBodyBytes on r.Body and strictly decodes it
+// into v. See httpx.DecodeJSON.
+func (s *Service) decodeJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	return httpx.DecodeJSON(w, r, v, s.maxBodyBytes)
+}
+
+// respondInternalError logs err against the request's ID and returns a
+// generic 500 that includes the same ID. See httpx.RespondInternalError.
+func (s *Service) respondInternalError(w http.ResponseWriter, r *http.Request, err error) {
+	httpx.RespondInternalError(s.logger, w, r, err)
+}
@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRoleAllows(t *testing.T) {
+	cases := []struct {
+		have, need Role
+		want       bool
+	}{
+		{RoleReader, RoleReader, true},
+		{RoleReader, RoleEditor, false},
+		{RoleEditor, RoleReader, true},
+		{RoleEditor, RoleAdmin, false},
+		{RoleAdmin, RoleAdmin, true},
+		{RoleAdmin, RoleReader, true},
+	}
+	for _, c := range cases {
+		if got := c.have.Allows(c.need); got != c.want {
+			t.Errorf("%s.Allows(%s) = %v, want %v", c.have, c.need, got, c.want)
+		}
+	}
+}
+
+func TestRoleForMethod(t *testing.T) {
+	cases := map[string]Role{
+		http.MethodGet:    RoleReader,
+		http.MethodPost:   RoleEditor,
+		http.MethodPut:    RoleEditor,
+		http.MethodDelete: RoleAdmin,
+	}
+	for method, want := range cases {
+		if got := RoleForMethod(method); got != want {
+			t.Errorf("RoleForMethod(%s) = %s, want %s", method, got, want)
+		}
+	}
+}
+
+func TestIssueAndParseToken(t *testing.T) {
+	s, err := NewService(nil, "test-secret", time.Minute, nil, 0)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+
+	token, err := s.issueToken(User{ID: 1, Username: "ada", Role: RoleEditor})
+	if err != nil {
+		t.Fatalf("issueToken: %v", err)
+	}
+
+	claims, err := s.parseToken(token)
+	if err != nil {
+		t.Fatalf("parseToken: %v", err)
+	}
+	if claims.UserID != 1 || claims.Username != "ada" || claims.Role != RoleEditor {
+		t.Errorf("unexpected claims: %+v", claims)
+	}
+}
+
+func TestParseTokenRejectsWrongSecret(t *testing.T) {
+	s, err := NewService(nil, "test-secret", time.Minute, nil, 0)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	other, err := NewService(nil, "other-secret", time.Minute, nil, 0)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+
+	token, err := s.issueToken(User{ID: 1, Username: "ada", Role: RoleAdmin})
+	if err != nil {
+		t.Fatalf("issueToken: %v", err)
+	}
+	if _, err := other.parseToken(token); err == nil {
+		t.Fatal("expected parseToken to reject a token signed with a different secret")
+	}
+}
+
+func TestParseTokenRejectsExpired(t *testing.T) {
+	s, err := NewService(nil, "test-secret", -time.Minute, nil, 0)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	token, err := s.issueToken(User{ID: 1, Username: "ada", Role: RoleReader})
+	if err != nil {
+		t.Fatalf("issueToken: %v", err)
+	}
+	if _, err := s.parseToken(token); err == nil {
+		t.Fatal("expected parseToken to reject an expired token")
+	}
+}
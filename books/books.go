@@ -0,0 +1,61 @@
+// Package books holds the book domain model and the repository/service
+// layers that back it: a Repository persists books, and a Service
+// layers validation, normalization, and event emission on top of it.
+package books
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Murluckk/vera/validate"
+)
+
+// Book is a single catalogued book.
+type Book struct {
+	ID        int64     `json:"id"`
+	Title     string    `json:"title"`
+	Author    string    `json:"author"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Validate checks Title and Author against minimum/maximum length,
+// satisfying validate.Validator. Title and Author are expected to
+// already be trimmed by the caller.
+func (b Book) Validate() validate.Errors {
+	errs := validate.Errors{}
+	validate.String(errs, "title", b.Title, minFieldLen, maxFieldLen)
+	validate.String(errs, "author", b.Author, minFieldLen, maxFieldLen)
+	return errs
+}
+
+// ErrNotFound is returned by Repository and Service methods when the
+// requested book doesn't exist.
+var ErrNotFound = errors.New("books: not found")
+
+// ValidationError is returned when a caller-supplied book fails
+// validation. Fields holds one message per invalid field; controllers
+// should translate it to a 422 response listing them.
+type ValidationError struct {
+	Fields validate.Errors
+}
+
+func (e ValidationError) Error() string { return e.Fields.Error() }
+
+// ListParams narrows and paginates List.
+type ListParams struct {
+	Limit   int
+	Offset  int
+	AfterID int64
+	Author  string
+	Query   string
+	Sort    string // "created_at" or "title"
+}
+
+// ListResult is the result of a List call: a page of books plus the
+// total number of books matching the params, ignoring Limit and Offset.
+// An AfterID cursor does narrow Total to the books after that ID.
+type ListResult struct {
+	Books []Book
+	Total int64
+}
@@ -0,0 +1,140 @@
+package books
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Murluckk/vera/events"
+)
+
+// memoryRepository is an in-process Repository used in tests. It
+// ignores the events.Execer passed to its write methods since there's
+// no real transaction to fold into.
+type memoryRepository struct {
+	mu     sync.Mutex
+	byID   map[int64]Book
+	nextID int64
+}
+
+// NewMemoryRepository returns a Repository backed by an in-memory map,
+// for use in tests.
+func NewMemoryRepository() Repository {
+	return &memoryRepository{byID: map[int64]Book{}}
+}
+
+func (r *memoryRepository) List(_ context.Context, p ListParams) (ListResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	matches := make([]Book, 0, len(r.byID))
+	for _, b := range r.byID {
+		if p.AfterID > 0 && b.ID <= p.AfterID {
+			continue
+		}
+		if author := strings.TrimSpace(p.Author); author != "" &&
+			!strings.Contains(strings.ToLower(b.Author), strings.ToLower(author)) {
+			continue
+		}
+		if term := strings.TrimSpace(p.Query); term != "" {
+			lower := strings.ToLower(term)
+			if !strings.Contains(strings.ToLower(b.Title), lower) && !strings.Contains(strings.ToLower(b.Author), lower) {
+				continue
+			}
+		}
+		matches = append(matches, b)
+	}
+
+	if p.Sort == "title" {
+		sort.Slice(matches, func(i, j int) bool {
+			if matches[i].Title != matches[j].Title {
+				return matches[i].Title < matches[j].Title
+			}
+			return matches[i].ID < matches[j].ID
+		})
+	} else {
+		sort.Slice(matches, func(i, j int) bool {
+			if !matches[i].CreatedAt.Equal(matches[j].CreatedAt) {
+				return matches[i].CreatedAt.Before(matches[j].CreatedAt)
+			}
+			return matches[i].ID < matches[j].ID
+		})
+	}
+
+	total := int64(len(matches))
+
+	limit := p.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	start := p.Offset
+	if p.AfterID > 0 {
+		start = 0
+	}
+	if start > len(matches) {
+		start = len(matches)
+	}
+	end := start + limit
+	if end > len(matches) {
+		end = len(matches)
+	}
+
+	return ListResult{Books: append([]Book{}, matches[start:end]...), Total: total}, nil
+}
+
+func (r *memoryRepository) GetByID(_ context.Context, id int64) (Book, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, ok := r.byID[id]
+	if !ok {
+		return Book{}, ErrNotFound
+	}
+	return b, nil
+}
+
+func (r *memoryRepository) Create(_ context.Context, _ events.Execer, b Book) (Book, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	now := time.Now()
+	b.ID = r.nextID
+	b.CreatedAt = now
+	b.UpdatedAt = now
+	r.byID[b.ID] = b
+	return b, nil
+}
+
+func (r *memoryRepository) Update(_ context.Context, _ events.Execer, id int64, b Book) (Book, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.byID[id]
+	if !ok {
+		return Book{}, ErrNotFound
+	}
+	existing.Title = b.Title
+	existing.Author = b.Author
+	existing.UpdatedAt = time.Now()
+	r.byID[id] = existing
+	return existing, nil
+}
+
+func (r *memoryRepository) Delete(_ context.Context, _ events.Execer, id int64) (Book, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, ok := r.byID[id]
+	if !ok {
+		return Book{}, ErrNotFound
+	}
+	delete(r.byID, id)
+	return b, nil
+}
@@ -0,0 +1,103 @@
+package books
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMemoryRepositoryCreateGetUpdateDelete(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+
+	created, err := repo.Create(ctx, nil, Book{Title: "Dune", Author: "Herbert"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if created.ID == 0 {
+		t.Fatal("expected a non-zero ID")
+	}
+
+	got, err := repo.GetByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got != created {
+		t.Errorf("GetByID = %+v, want %+v", got, created)
+	}
+
+	updated, err := repo.Update(ctx, nil, created.ID, Book{Title: "Dune Messiah", Author: "Herbert"})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if updated.Title != "Dune Messiah" {
+		t.Errorf("Title = %q, want Dune Messiah", updated.Title)
+	}
+
+	if _, err := repo.Delete(ctx, nil, created.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, created.ID); err != ErrNotFound {
+		t.Errorf("GetByID after delete = %v, want ErrNotFound", err)
+	}
+}
+
+func TestMemoryRepositoryGetByIDNotFound(t *testing.T) {
+	repo := NewMemoryRepository()
+	if _, err := repo.GetByID(context.Background(), 1); err != ErrNotFound {
+		t.Errorf("GetByID = %v, want ErrNotFound", err)
+	}
+}
+
+func TestMemoryRepositoryListFiltersAndPaginates(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+
+	seed := []Book{
+		{Title: "Dune", Author: "Frank Herbert"},
+		{Title: "Foundation", Author: "Isaac Asimov"},
+		{Title: "Dune Messiah", Author: "Frank Herbert"},
+	}
+	for _, b := range seed {
+		if _, err := repo.Create(ctx, nil, b); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	result, err := repo.List(ctx, ListParams{Author: "herbert"})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if result.Total != 2 {
+		t.Errorf("Total = %d, want 2", result.Total)
+	}
+
+	result, err = repo.List(ctx, ListParams{Limit: 1})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(result.Books) != 1 {
+		t.Fatalf("len(Books) = %d, want 1", len(result.Books))
+	}
+	if result.Total != 3 {
+		t.Errorf("Total = %d, want 3", result.Total)
+	}
+}
+
+func TestInputNormalizeRejectsBlank(t *testing.T) {
+	if _, err := (Input{Title: "  ", Author: "Herbert"}).normalize(); err == nil {
+		t.Fatal("expected a ValidationError for blank title")
+	}
+	if _, err := (Input{Title: "Dune", Author: ""}).normalize(); err == nil {
+		t.Fatal("expected a ValidationError for blank author")
+	}
+}
+
+func TestInputNormalizeTrims(t *testing.T) {
+	b, err := (Input{Title: "  Dune  ", Author: " Herbert "}).normalize()
+	if err != nil {
+		t.Fatalf("normalize: %v", err)
+	}
+	if b.Title != "Dune" || b.Author != "Herbert" {
+		t.Errorf("normalize = %+v, want trimmed fields", b)
+	}
+}
@@ -0,0 +1,163 @@
+package books
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Murluckk/vera/events"
+)
+
+type postgresRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRepository returns a Repository backed by the books table
+// in db.
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &postgresRepository{db: db}
+}
+
+var sortColumns = map[string]string{
+	"created_at": "created_at",
+	"title":      "title",
+}
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+func (r *postgresRepository) List(ctx context.Context, p ListParams) (ListResult, error) {
+	limit := p.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	sortCol, ok := sortColumns[p.Sort]
+	if !ok {
+		sortCol = sortColumns["created_at"]
+	}
+
+	var conditions []string
+	var args []any
+
+	if author := strings.TrimSpace(p.Author); author != "" {
+		args = append(args, "%"+author+"%")
+		conditions = append(conditions, fmt.Sprintf("author ILIKE $%d", len(args)))
+	}
+	if term := strings.TrimSpace(p.Query); term != "" {
+		args = append(args, "%"+term+"%")
+		conditions = append(conditions, fmt.Sprintf("(title ILIKE $%d OR author ILIKE $%d)", len(args), len(args)))
+	}
+	if p.AfterID > 0 {
+		args = append(args, p.AfterID)
+		conditions = append(conditions, fmt.Sprintf("id > $%d", len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int64
+	countQuery := "SELECT COUNT(*) FROM books" + where
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return ListResult{}, fmt.Errorf("books: count: %w", err)
+	}
+
+	listArgs := append(append([]any{}, args...), limit)
+	query := fmt.Sprintf(
+		"SELECT id, title, author, created_at, updated_at FROM books%s ORDER BY %s, id LIMIT $%d",
+		where, sortCol, len(listArgs),
+	)
+	if p.AfterID == 0 && p.Offset > 0 {
+		listArgs = append(listArgs, p.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(listArgs))
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, listArgs...)
+	if err != nil {
+		return ListResult{}, fmt.Errorf("books: list: %w", err)
+	}
+	defer rows.Close()
+
+	list := []Book{}
+	for rows.Next() {
+		var b Book
+		if err := rows.Scan(&b.ID, &b.Title, &b.Author, &b.CreatedAt, &b.UpdatedAt); err != nil {
+			return ListResult{}, fmt.Errorf("books: scan: %w", err)
+		}
+		list = append(list, b)
+	}
+	if err := rows.Err(); err != nil {
+		return ListResult{}, err
+	}
+
+	return ListResult{Books: list, Total: total}, nil
+}
+
+func (r *postgresRepository) GetByID(ctx context.Context, id int64) (Book, error) {
+	var b Book
+	err := r.db.QueryRowContext(ctx,
+		`SELECT id, title, author, created_at, updated_at FROM books WHERE id = $1`, id,
+	).Scan(&b.ID, &b.Title, &b.Author, &b.CreatedAt, &b.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Book{}, ErrNotFound
+	}
+	if err != nil {
+		return Book{}, fmt.Errorf("books: get by id: %w", err)
+	}
+	return b, nil
+}
+
+func (r *postgresRepository) Create(ctx context.Context, exec events.Execer, b Book) (Book, error) {
+	var created Book
+	err := exec.QueryRowContext(ctx,
+		`INSERT INTO books (title, author) VALUES ($1, $2) RETURNING id, title, author, created_at, updated_at`,
+		b.Title, b.Author,
+	).Scan(&created.ID, &created.Title, &created.Author, &created.CreatedAt, &created.UpdatedAt)
+	if err != nil {
+		return Book{}, fmt.Errorf("books: create: %w", err)
+	}
+	return created, nil
+}
+
+func (r *postgresRepository) Update(ctx context.Context, exec events.Execer, id int64, b Book) (Book, error) {
+	var updated Book
+	err := exec.QueryRowContext(ctx,
+		`UPDATE books SET title = $1, author = $2, updated_at = NOW() WHERE id = $3
+		 RETURNING id, title, author, created_at, updated_at`,
+		b.Title, b.Author, id,
+	).Scan(&updated.ID, &updated.Title, &updated.Author, &updated.CreatedAt, &updated.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Book{}, ErrNotFound
+	}
+	if err != nil {
+		return Book{}, fmt.Errorf("books: update: %w", err)
+	}
+	return updated, nil
+}
+
+func (r *postgresRepository) Delete(ctx context.Context, exec events.Execer, id int64) (Book, error) {
+	var b Book
+	err := exec.QueryRowContext(ctx,
+		`SELECT id, title, author, created_at, updated_at FROM books WHERE id = $1`, id,
+	).Scan(&b.ID, &b.Title, &b.Author, &b.CreatedAt, &b.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Book{}, ErrNotFound
+	}
+	if err != nil {
+		return Book{}, fmt.Errorf("books: delete: select: %w", err)
+	}
+
+	if _, err := exec.ExecContext(ctx, `DELETE FROM books WHERE id = $1`, id); err != nil {
+		return Book{}, fmt.Errorf("books: delete: %w", err)
+	}
+	return b, nil
+}
@@ -0,0 +1,18 @@
+package books
+
+import (
+	"context"
+
+	"github.com/Murluckk/vera/events"
+)
+
+// Repository persists books. Create, Update, and Delete accept an
+// events.Execer so a Service can fold the write into a transaction
+// shared with an audit event write.
+type Repository interface {
+	List(ctx context.Context, p ListParams) (ListResult, error)
+	GetByID(ctx context.Context, id int64) (Book, error)
+	Create(ctx context.Context, exec events.Execer, b Book) (Book, error)
+	Update(ctx context.Context, exec events.Execer, id int64, b Book) (Book, error)
+	Delete(ctx context.Context, exec events.Execer, id int64) (Book, error)
+}
@@ -0,0 +1,130 @@
+package books
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/Murluckk/vera/events"
+)
+
+// Title and Author are each constrained to this many runes.
+const (
+	minFieldLen = 1
+	maxFieldLen = 255
+)
+
+// Service holds the business rules around books: input validation and
+// normalization, and emitting an audit event alongside every write in
+// the same transaction as the write itself.
+type Service struct {
+	db   *sql.DB
+	repo Repository
+}
+
+// NewService builds a Service. db is used to open the transactions
+// that wrap repo writes and their audit events. db may be nil when repo
+// doesn't need one (e.g. NewMemoryRepository in tests); writes then run
+// untransacted and emit no audit event, since there's no real
+// connection for events.Record to write through.
+func NewService(db *sql.DB, repo Repository) *Service {
+	return &Service{db: db, repo: repo}
+}
+
+// withTx runs fn over a transaction on s.db, committing on success. If
+// s.db is nil, fn runs directly with a nil events.Execer instead.
+func (s *Service) withTx(ctx context.Context, fn func(events.Execer) error) error {
+	if s.db == nil {
+		return fn(nil)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("books: begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Input is the caller-supplied fields for Create and Update.
+type Input struct {
+	Title  string
+	Author string
+}
+
+func (in Input) normalize() (Book, error) {
+	b := Book{
+		Title:  strings.TrimSpace(in.Title),
+		Author: strings.TrimSpace(in.Author),
+	}
+
+	if errs := b.Validate(); len(errs) > 0 {
+		return Book{}, ValidationError{Fields: errs}
+	}
+	return b, nil
+}
+
+func (s *Service) List(ctx context.Context, p ListParams) (ListResult, error) {
+	return s.repo.List(ctx, p)
+}
+
+func (s *Service) GetByID(ctx context.Context, id int64) (Book, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *Service) Create(ctx context.Context, in Input) (Book, error) {
+	b, err := in.normalize()
+	if err != nil {
+		return Book{}, err
+	}
+
+	var created Book
+	err = s.withTx(ctx, func(exec events.Execer) error {
+		var err error
+		created, err = s.repo.Create(ctx, exec, b)
+		if err != nil || exec == nil {
+			return err
+		}
+		return events.Record(ctx, exec, created.ID, events.Created, created)
+	})
+	if err != nil {
+		return Book{}, err
+	}
+	return created, nil
+}
+
+func (s *Service) Update(ctx context.Context, id int64, in Input) (Book, error) {
+	b, err := in.normalize()
+	if err != nil {
+		return Book{}, err
+	}
+
+	var updated Book
+	err = s.withTx(ctx, func(exec events.Execer) error {
+		var err error
+		updated, err = s.repo.Update(ctx, exec, id, b)
+		if err != nil || exec == nil {
+			return err
+		}
+		return events.Record(ctx, exec, updated.ID, events.Updated, updated)
+	})
+	if err != nil {
+		return Book{}, err
+	}
+	return updated, nil
+}
+
+func (s *Service) Delete(ctx context.Context, id int64) error {
+	return s.withTx(ctx, func(exec events.Execer) error {
+		deleted, err := s.repo.Delete(ctx, exec, id)
+		if err != nil || exec == nil {
+			return err
+		}
+		return events.Record(ctx, exec, id, events.Deleted, deleted)
+	})
+}
@@ -0,0 +1,42 @@
+package books
+
+import (
+	"context"
+	"testing"
+)
+
+// TestServiceOverMemoryRepository exercises Service wired over
+// NewMemoryRepository with a nil db, confirming writes work
+// untransacted instead of panicking on a nil *sql.DB.
+func TestServiceOverMemoryRepository(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(nil, NewMemoryRepository())
+
+	created, err := svc.Create(ctx, Input{Title: "Dune", Author: "Herbert"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	got, err := svc.GetByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got != created {
+		t.Errorf("GetByID = %+v, want %+v", got, created)
+	}
+
+	updated, err := svc.Update(ctx, created.ID, Input{Title: "Dune Messiah", Author: "Herbert"})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if updated.Title != "Dune Messiah" {
+		t.Errorf("Title = %q, want Dune Messiah", updated.Title)
+	}
+
+	if err := svc.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := svc.GetByID(ctx, created.ID); err != ErrNotFound {
+		t.Errorf("GetByID after delete = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,127 @@
+// Package events owns the book_events audit timeline: recording
+// events as part of a caller-supplied transaction, and listing them
+// back out for the HTTP layer.
+package events
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+const (
+	Created  = "created"
+	Updated  = "updated"
+	Deleted  = "deleted"
+	Tagged   = "tagged"
+	Untagged = "untagged"
+)
+
+// Event is one entry in a book's append-only audit timeline.
+type Event struct {
+	ID         int64     `json:"id"`
+	BookID     int64     `json:"book_id"`
+	EventType  string    `json:"event_type"`
+	Payload    NullJSON  `json:"payload"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// Execer is satisfied by both *sql.DB and *sql.Tx. Record accepts it
+// so callers can fold an event write into their own transaction.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Record inserts an audit event for bookID as part of exec (typically
+// a transaction shared with the write that triggered it). payload is
+// marshaled to JSON, or stored as SQL NULL when nil.
+func Record(ctx context.Context, exec Execer, bookID int64, eventType string, payload any) error {
+	p, err := newPayload(payload)
+	if err != nil {
+		return fmt.Errorf("events: marshal payload: %w", err)
+	}
+	_, err = exec.ExecContext(ctx,
+		`INSERT INTO book_events (book_id, event_type, payload) VALUES ($1, $2, $3)`,
+		bookID, eventType, p,
+	)
+	if err != nil {
+		return fmt.Errorf("events: insert: %w", err)
+	}
+	return nil
+}
+
+// Filter narrows the global event feed; zero values mean unfiltered.
+type Filter struct {
+	BookID    int64
+	EventType string
+	Since     time.Time
+}
+
+// ListForBook returns every event for bookID, oldest first.
+func ListForBook(ctx context.Context, db *sql.DB, bookID int64) ([]Event, error) {
+	rows, err := db.QueryContext(ctx,
+		`SELECT id, book_id, event_type, payload, occurred_at FROM book_events
+		 WHERE book_id = $1 ORDER BY occurred_at, id`,
+		bookID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("events: list for book: %w", err)
+	}
+	defer rows.Close()
+	return scan(rows)
+}
+
+// List returns the global audit feed, oldest first, narrowed by f.
+func List(ctx context.Context, db *sql.DB, f Filter) ([]Event, error) {
+	var conditions []string
+	var args []any
+
+	if f.BookID > 0 {
+		args = append(args, f.BookID)
+		conditions = append(conditions, fmt.Sprintf("book_id = $%d", len(args)))
+	}
+	if f.EventType != "" {
+		args = append(args, f.EventType)
+		conditions = append(conditions, fmt.Sprintf("event_type = $%d", len(args)))
+	}
+	if !f.Since.IsZero() {
+		args = append(args, f.Since)
+		conditions = append(conditions, fmt.Sprintf("occurred_at >= $%d", len(args)))
+	}
+
+	query := `SELECT id, book_id, event_type, payload, occurred_at FROM book_events`
+	for i, c := range conditions {
+		if i == 0 {
+			query += " WHERE "
+		} else {
+			query += " AND "
+		}
+		query += c
+	}
+	query += " ORDER BY occurred_at, id"
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("events: list: %w", err)
+	}
+	defer rows.Close()
+	return scan(rows)
+}
+
+func scan(rows *sql.Rows) ([]Event, error) {
+	events := []Event{}
+	for rows.Next() {
+		var e Event
+		if err := rows.Scan(&e.ID, &e.BookID, &e.EventType, &e.Payload, &e.OccurredAt); err != nil {
+			return nil, fmt.Errorf("events: scan: %w", err)
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
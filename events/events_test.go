@@ -0,0 +1,126 @@
+package events
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// recordingDriver is a minimal database/sql/driver.Driver that records
+// every statement executed against it instead of talking to a real
+// database, so Record can be exercised through its real ExecContext
+// write path without a Postgres connection.
+type recordingDriver struct {
+	queries []string
+	args    [][]driver.Value
+}
+
+func (d *recordingDriver) Open(name string) (driver.Conn, error) {
+	return &recordingConn{d: d}, nil
+}
+
+type recordingConn struct{ d *recordingDriver }
+
+func (c *recordingConn) Prepare(query string) (driver.Stmt, error) {
+	return &recordingStmt{conn: c, query: query}, nil
+}
+func (c *recordingConn) Close() error { return nil }
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("recordingConn: transactions not supported")
+}
+
+type recordingStmt struct {
+	conn  *recordingConn
+	query string
+}
+
+func (s *recordingStmt) Close() error  { return nil }
+func (s *recordingStmt) NumInput() int { return -1 }
+func (s *recordingStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.conn.d.queries = append(s.conn.d.queries, s.query)
+	s.conn.d.args = append(s.conn.d.args, args)
+	return driver.RowsAffected(1), nil
+}
+func (s *recordingStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return nil, errors.New("recordingStmt: Query not supported")
+}
+
+// TestRecordInsertsBookEvent exercises Record through its real
+// ExecContext write path, confirming a write produces exactly one
+// book_events insert carrying the given book ID and event type.
+func TestRecordInsertsBookEvent(t *testing.T) {
+	drv := &recordingDriver{}
+	sql.Register(t.Name(), drv)
+	db, err := sql.Open(t.Name(), "")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer db.Close()
+
+	if err := Record(context.Background(), db, 42, Created, map[string]string{"title": "Dune"}); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+
+	if len(drv.queries) != 1 {
+		t.Fatalf("got %d statements executed, want exactly 1", len(drv.queries))
+	}
+	if !strings.Contains(drv.queries[0], "INSERT INTO book_events") {
+		t.Errorf("query = %q, want an INSERT INTO book_events", drv.queries[0])
+	}
+
+	args := drv.args[0]
+	if len(args) != 3 {
+		t.Fatalf("got %d args, want 3 (book_id, event_type, payload)", len(args))
+	}
+	if args[0] != int64(42) {
+		t.Errorf("book_id = %v, want 42", args[0])
+	}
+	if args[1] != Created {
+		t.Errorf("event_type = %v, want %q", args[1], Created)
+	}
+}
+
+// TestPackageExposesNoMutationEntryPoint guards the append-only
+// invariant of the book_events timeline: the only exported top-level
+// funcs must be Record (an insert), List, and ListForBook — no Update
+// or Delete.
+func TestPackageExposesNoMutationEntryPoint(t *testing.T) {
+	entries, err := os.ReadDir(".")
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+
+	fset := token.NewFileSet()
+	var exported []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			t.Fatalf("ParseFile(%s): %v", name, err)
+		}
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || !fn.Name.IsExported() {
+				continue
+			}
+			exported = append(exported, fn.Name.Name)
+		}
+	}
+	sort.Strings(exported)
+
+	want := []string{"List", "ListForBook", "Record"}
+	if strings.Join(exported, ",") != strings.Join(want, ",") {
+		t.Fatalf("exported funcs = %v, want %v", exported, want)
+	}
+}
@@ -0,0 +1,70 @@
+package events
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// NullJSON is an optional JSON value, used for columns like
+// book_events.payload that may legitimately be absent rather than an
+// empty object. It implements sql.Scanner/driver.Valuer for JSONB
+// columns and marshals to/from JSON null.
+type NullJSON struct {
+	Raw   json.RawMessage
+	Valid bool
+}
+
+func (n NullJSON) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return n.Raw, nil
+}
+
+func (n *NullJSON) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		n.Raw, n.Valid = nil, false
+		return nil
+	}
+	n.Raw = append(json.RawMessage(nil), data...)
+	n.Valid = true
+	return nil
+}
+
+func (n *NullJSON) Scan(value any) error {
+	if value == nil {
+		n.Raw, n.Valid = nil, false
+		return nil
+	}
+	switch v := value.(type) {
+	case []byte:
+		n.Raw = append(json.RawMessage(nil), v...)
+	case string:
+		n.Raw = json.RawMessage(v)
+	default:
+		return fmt.Errorf("nullable: unsupported Scan type %T", value)
+	}
+	n.Valid = true
+	return nil
+}
+
+func (n NullJSON) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return []byte(n.Raw), nil
+}
+
+// newPayload marshals v into a valid NullJSON, or returns the zero
+// (invalid/null) value when v is nil.
+func newPayload(v any) (NullJSON, error) {
+	if v == nil {
+		return NullJSON{}, nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return NullJSON{}, err
+	}
+	return NullJSON{Raw: raw, Valid: true}, nil
+}
@@ -0,0 +1,69 @@
+package events
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNullJSONRoundTrip(t *testing.T) {
+	n, err := newPayload(map[string]string{"title": "Dune"})
+	if err != nil {
+		t.Fatalf("newPayload: %v", err)
+	}
+
+	out, err := json.Marshal(n)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var got NullJSON
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if !got.Valid {
+		t.Fatal("expected Valid to be true")
+	}
+
+	var m map[string]string
+	if err := json.Unmarshal(got.Raw, &m); err != nil {
+		t.Fatalf("Unmarshal payload: %v", err)
+	}
+	if m["title"] != "Dune" {
+		t.Errorf("title = %q, want Dune", m["title"])
+	}
+}
+
+func TestNullJSONNil(t *testing.T) {
+	n, err := newPayload(nil)
+	if err != nil {
+		t.Fatalf("newPayload: %v", err)
+	}
+	if n.Valid {
+		t.Fatal("expected Valid to be false for nil payload")
+	}
+
+	out, err := json.Marshal(n)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if string(out) != "null" {
+		t.Errorf("Marshal = %s, want null", out)
+	}
+}
+
+func TestNullJSONScan(t *testing.T) {
+	var n NullJSON
+	if err := n.Scan(nil); err != nil {
+		t.Fatalf("Scan(nil): %v", err)
+	}
+	if n.Valid {
+		t.Fatal("expected Valid to be false after Scan(nil)")
+	}
+
+	if err := n.Scan([]byte(`{"k":"v"}`)); err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if !n.Valid {
+		t.Fatal("expected Valid to be true after Scan")
+	}
+}
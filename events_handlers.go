@@ -0,0 +1,57 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Murluckk/vera/events"
+)
+
+// listBookEvents handles GET /books/{id}/events.
+func (s *server) listBookEvents(w http.ResponseWriter, r *http.Request) {
+	id, err := bookID(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	evts, err := events.ListForBook(r.Context(), s.db, id)
+	if err != nil {
+		respondInternalError(w, r, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]any{"data": evts})
+}
+
+// listEvents handles GET /events?book_id=&type=&since= — the global
+// audit feed across every book.
+func (s *server) listEvents(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	var filter events.Filter
+	if v := q.Get("book_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid book_id")
+			return
+		}
+		filter.BookID = id
+	}
+	filter.EventType = q.Get("type")
+	if v := q.Get("since"); v != "" {
+		since, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid since, expected RFC3339")
+			return
+		}
+		filter.Since = since
+	}
+
+	evts, err := events.List(r.Context(), s.db, filter)
+	if err != nil {
+		respondInternalError(w, r, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]any{"data": evts})
+}
@@ -0,0 +1,73 @@
+// Package httpx holds the JSON response and request-decoding helpers
+// shared by the auth and book HTTP layers, so both enforce the same
+// body-size limit and error envelope instead of maintaining their own
+// copies.
+package httpx
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/Murluckk/vera/logging"
+	"github.com/Murluckk/vera/validate"
+)
+
+// RespondJSON writes v as a JSON body with the given status code.
+func RespondJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Default().Error("httpx: write response", "err", err)
+	}
+}
+
+// RespondError writes a {"error": msg} body with the given status code.
+func RespondError(w http.ResponseWriter, status int, msg string) {
+	RespondJSON(w, status, map[string]string{"error": msg})
+}
+
+// RespondValidationErrors returns a 422 with one message per invalid
+// field, e.g. {"errors":{"title":"must be 1-255 chars"}}.
+func RespondValidationErrors(w http.ResponseWriter, errs validate.Errors) {
+	RespondJSON(w, http.StatusUnprocessableEntity, map[string]any{"errors": errs})
+}
+
+// RespondInternalError logs err against the request's ID (see
+// logging.RequestID) and returns a generic 500 that includes the same
+// ID, so a caller can report it without leaking internal details.
+func RespondInternalError(logger *slog.Logger, w http.ResponseWriter, r *http.Request, err error) {
+	requestID := logging.RequestID(r.Context())
+	logger.Error("internal error", "err", err, "request_id", requestID)
+	RespondJSON(w, http.StatusInternalServerError, map[string]string{
+		"error":      "internal server error",
+		"request_id": requestID,
+	})
+}
+
+// DecodeJSON enforces maxBodyBytes on r.Body and strictly decodes it
+// into v, rejecting unknown fields and any trailing data after the
+// first JSON value. It writes the appropriate error response itself and
+// reports whether decoding succeeded.
+func DecodeJSON(w http.ResponseWriter, r *http.Request, v any, maxBodyBytes int64) bool {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodyBytes)
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			RespondError(w, http.StatusRequestEntityTooLarge, "request body too large")
+			return false
+		}
+		RespondError(w, http.StatusBadRequest, "invalid JSON")
+		return false
+	}
+	if err := dec.Decode(&struct{}{}); err != io.EOF {
+		RespondError(w, http.StatusBadRequest, "invalid JSON: unexpected trailing data")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,111 @@
+// Package logging provides a JSON slog logger and a request-scoped
+// tracing middleware: every request gets a freshly generated UUID
+// request ID, propagated via the X-Request-ID response header (an
+// incoming X-Request-ID is not reused), and one structured log line at
+// completion.
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NewLogger builds a JSON slog.Logger writing to stdout at the given
+// level ("debug", "info", "warn", "error"; unrecognized values fall
+// back to "info").
+func NewLogger(level string) *slog.Logger {
+	var lvl slog.Level
+	switch strings.ToLower(level) {
+	case "debug":
+		lvl = slog.LevelDebug
+	case "warn", "warning":
+		lvl = slog.LevelWarn
+	case "error":
+		lvl = slog.LevelError
+	default:
+		lvl = slog.LevelInfo
+	}
+	handler := slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: lvl})
+	return slog.New(handler)
+}
+
+type contextKey int
+
+const requestIDKey contextKey = iota
+
+// RequestID returns the request ID stashed in ctx by Middleware, or ""
+// if there isn't one (e.g. outside of an HTTP request).
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// Middleware generates a request ID (propagated via the X-Request-ID
+// response header and the request context), and logs one JSON line
+// per request at completion.
+func Middleware(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			requestID := uuid.NewString()
+			w.Header().Set("X-Request-ID", requestID)
+			ctx := context.WithValue(r.Context(), requestIDKey, requestID)
+
+			sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(sw, r.WithContext(ctx))
+
+			logger.Info("request",
+				"request_id", requestID,
+				"method", r.Method,
+				"path", r.URL.Path,
+				"status", sw.status,
+				"duration_ms", time.Since(start).Milliseconds(),
+				"remote_addr", remoteAddr(r),
+				"user_agent", r.UserAgent(),
+			)
+		})
+	}
+}
+
+// remoteAddr prefers the first hop of X-Forwarded-For, falling back
+// to the TCP peer address.
+func remoteAddr(r *http.Request) string {
+	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+		if first, _, ok := strings.Cut(xff, ","); ok {
+			return strings.TrimSpace(first)
+		}
+		return strings.TrimSpace(xff)
+	}
+	return r.RemoteAddr
+}
+
+// statusWriter captures the status code written by a downstream
+// handler so Middleware can log it.
+type statusWriter struct {
+	http.ResponseWriter
+	status  int
+	started bool
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	if !w.started {
+		w.status = status
+		w.started = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	if !w.started {
+		w.status = http.StatusOK
+		w.started = true
+	}
+	return w.ResponseWriter.Write(b)
+}
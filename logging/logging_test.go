@@ -0,0 +1,25 @@
+package logging
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRemoteAddrPrefersForwardedFor(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = "10.0.0.1:1234"
+	r.Header.Set("X-Forwarded-For", "203.0.113.5, 10.0.0.2")
+
+	if got := remoteAddr(r); got != "203.0.113.5" {
+		t.Errorf("remoteAddr = %q, want 203.0.113.5", got)
+	}
+}
+
+func TestRemoteAddrFallsBackToRemoteAddr(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = "10.0.0.1:1234"
+
+	if got := remoteAddr(r); got != "10.0.0.1:1234" {
+		t.Errorf("remoteAddr = %q, want 10.0.0.1:1234", got)
+	}
+}
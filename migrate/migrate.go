@@ -0,0 +1,257 @@
+// Package migrate applies versioned SQL migrations loaded from an
+// fs.FS (typically an embedded directory of .sql files) and tracks
+// which versions have been applied in a schema_migrations table.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+)
+
+// Migration is one up/down pair, identified by a sortable version
+// prefix such as "20240101120000".
+type Migration struct {
+	Version string
+	Name    string
+	UpSQL   string
+	DownSQL string
+}
+
+var filenamePattern = regexp.MustCompile(`^(\d+)_([a-zA-Z0-9_]+)\.(up|down)\.sql$`)
+
+// Load reads every *.up.sql/*.down.sql pair in fsys and returns them
+// sorted ascending by version. It returns an error if an up file has
+// no matching down file or vice versa.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("migrate: read migrations dir: %w", err)
+	}
+
+	byVersion := map[string]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		m := filenamePattern.FindStringSubmatch(entry.Name())
+		if m == nil {
+			continue
+		}
+		version, name, direction := m[1], m[2], m[3]
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: name}
+			byVersion[version] = mig
+		}
+
+		contents, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("migrate: read %s: %w", entry.Name(), err)
+		}
+		switch direction {
+		case "up":
+			mig.UpSQL = string(contents)
+		case "down":
+			mig.DownSQL = string(contents)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.UpSQL == "" {
+			return nil, fmt.Errorf("migrate: %s_%s is missing an .up.sql file", mig.Version, mig.Name)
+		}
+		if mig.DownSQL == "" {
+			return nil, fmt.Errorf("migrate: %s_%s is missing a .down.sql file", mig.Version, mig.Name)
+		}
+		migrations = append(migrations, *mig)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// Status describes one migration's applied state.
+type Status struct {
+	Version string
+	Name    string
+	Applied bool
+}
+
+// Runner applies and rolls back migrations against db, recording
+// progress in the schema_migrations table.
+type Runner struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// NewRunner builds a Runner over an already-loaded, version-sorted set
+// of migrations.
+func NewRunner(db *sql.DB, migrations []Migration) *Runner {
+	return &Runner{db: db, migrations: migrations}
+}
+
+// EnsureVersionTable creates the schema_migrations bookkeeping table if
+// it does not already exist.
+func (r *Runner) EnsureVersionTable(ctx context.Context) error {
+	const ddl = `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+	version TEXT PRIMARY KEY,
+	name TEXT NOT NULL,
+	applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+`
+	if _, err := r.db.ExecContext(ctx, ddl); err != nil {
+		return fmt.Errorf("migrate: create schema_migrations: %w", err)
+	}
+	return nil
+}
+
+func (r *Runner) appliedVersions(ctx context.Context) (map[string]bool, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT version FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("migrate: list applied versions: %w", err)
+	}
+	defer rows.Close()
+
+	applied := map[string]bool{}
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("migrate: scan version: %w", err)
+		}
+		applied[version] = true
+	}
+	return applied, rows.Err()
+}
+
+// CheckNotNewer fails if the database has a migration applied that
+// this binary doesn't know about, which means the binary is older
+// than the database it's connecting to.
+func (r *Runner) CheckNotNewer(ctx context.Context) error {
+	applied, err := r.appliedVersions(ctx)
+	if err != nil {
+		return err
+	}
+
+	known := map[string]bool{}
+	for _, m := range r.migrations {
+		known[m.Version] = true
+	}
+	for version := range applied {
+		if !known[version] {
+			return fmt.Errorf("migrate: database has migration %s applied that this binary does not know about; upgrade the binary before starting", version)
+		}
+	}
+	return nil
+}
+
+// Up applies up to steps pending migrations in version order. steps
+// <= 0 means apply all pending migrations. It returns how many were
+// applied.
+func (r *Runner) Up(ctx context.Context, steps int) (int, error) {
+	applied, err := r.appliedVersions(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, m := range r.migrations {
+		if applied[m.Version] {
+			continue
+		}
+		if steps > 0 && count >= steps {
+			break
+		}
+		if err := r.applyUp(ctx, m); err != nil {
+			return count, fmt.Errorf("migrate: apply %s_%s: %w", m.Version, m.Name, err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+func (r *Runner) applyUp(ctx context.Context, m Migration) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, m.UpSQL); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`,
+		m.Version, m.Name,
+	); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Down rolls back up to steps applied migrations, most recent first.
+// steps <= 0 defaults to 1. It returns how many were rolled back.
+func (r *Runner) Down(ctx context.Context, steps int) (int, error) {
+	if steps <= 0 {
+		steps = 1
+	}
+
+	applied, err := r.appliedVersions(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for i := len(r.migrations) - 1; i >= 0 && count < steps; i-- {
+		m := r.migrations[i]
+		if !applied[m.Version] {
+			continue
+		}
+		if err := r.applyDown(ctx, m); err != nil {
+			return count, fmt.Errorf("migrate: rollback %s_%s: %w", m.Version, m.Name, err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+func (r *Runner) applyDown(ctx context.Context, m Migration) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, m.DownSQL); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = $1`, m.Version); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Status reports, for every known migration, whether it has been
+// applied, in version order.
+func (r *Runner) Status(ctx context.Context) ([]Status, error) {
+	applied, err := r.appliedVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(r.migrations))
+	for _, m := range r.migrations {
+		statuses = append(statuses, Status{
+			Version: m.Version,
+			Name:    m.Name,
+			Applied: applied[m.Version],
+		})
+	}
+	return statuses, nil
+}
@@ -0,0 +1,58 @@
+package migrate
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/Murluckk/vera/migrations"
+)
+
+func TestLoadOrdersByVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"20240105000000_add_users.up.sql":   {Data: []byte("CREATE TABLE users ();")},
+		"20240105000000_add_users.down.sql": {Data: []byte("DROP TABLE users;")},
+		"20240101120000_create_books.up.sql":   {Data: []byte("CREATE TABLE books ();")},
+		"20240101120000_create_books.down.sql": {Data: []byte("DROP TABLE books;")},
+	}
+
+	migrations, err := Load(fsys)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(migrations) != 2 {
+		t.Fatalf("len(migrations) = %d, want 2", len(migrations))
+	}
+	if migrations[0].Name != "create_books" || migrations[1].Name != "add_users" {
+		t.Errorf("unexpected order: %s, %s", migrations[0].Name, migrations[1].Name)
+	}
+}
+
+func TestLoadMissingDownFile(t *testing.T) {
+	fsys := fstest.MapFS{
+		"20240101120000_create_books.up.sql": {Data: []byte("CREATE TABLE books ();")},
+	}
+
+	if _, err := Load(fsys); err == nil {
+		t.Fatal("expected an error for a migration missing its .down.sql file")
+	}
+}
+
+// TestLoadParsesEmbeddedMigrations guards against filenamePattern
+// silently matching nothing against the real migrations/ directory,
+// which would boot the server against an empty, un-migrated database.
+func TestLoadParsesEmbeddedMigrations(t *testing.T) {
+	migs, err := Load(migrations.FS)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(migs) != 3 {
+		t.Fatalf("len(migrations) = %d, want 3", len(migs))
+	}
+	names := []string{migs[0].Name, migs[1].Name, migs[2].Name}
+	want := []string{"create_books", "add_users", "add_tags_and_events"}
+	for i, name := range want {
+		if names[i] != name {
+			t.Errorf("migrations[%d].Name = %q, want %q", i, names[i], name)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+// Package migrations embeds the SQL migration files applied by the
+// migrate package. Keep this package free of anything but the embed
+// directive so the embedded file set stays exactly the .sql files below.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
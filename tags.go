@@ -0,0 +1,149 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Murluckk/vera/events"
+)
+
+// Tag is a label that can be attached to many books.
+type Tag struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type tagPayload struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// addBookTag handles POST /books/{id}/tags. It creates the tag by slug,
+// or, if the slug exists, overwrites that tag's name with the given
+// one; attaches it to the book; and records a "tagged" event only when
+// the tag was newly attached, all in one transaction.
+func (s *server) addBookTag(w http.ResponseWriter, r *http.Request) {
+	id, err := bookID(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	var payload tagPayload
+	if !s.decodeJSON(w, r, &payload) {
+		return
+	}
+	if payload.Name == "" || payload.Slug == "" {
+		respondError(w, http.StatusBadRequest, "name and slug are required")
+		return
+	}
+
+	ctx := r.Context()
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		respondInternalError(w, r, err)
+		return
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM books WHERE id = $1)`, id).Scan(&exists); err != nil {
+		respondInternalError(w, r, err)
+		return
+	}
+	if !exists {
+		respondError(w, http.StatusNotFound, "book not found")
+		return
+	}
+
+	var tag Tag
+	err = tx.QueryRowContext(ctx,
+		`INSERT INTO tags (name, slug) VALUES ($1, $2)
+		 ON CONFLICT (slug) DO UPDATE SET name = EXCLUDED.name
+		 RETURNING id, name, slug`,
+		payload.Name, payload.Slug,
+	).Scan(&tag.ID, &tag.Name, &tag.Slug)
+	if err != nil {
+		respondInternalError(w, r, err)
+		return
+	}
+
+	res, err := tx.ExecContext(ctx,
+		`INSERT INTO book_tags (book_id, tag_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+		id, tag.ID,
+	)
+	if err != nil {
+		respondInternalError(w, r, err)
+		return
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		respondInternalError(w, r, err)
+		return
+	}
+
+	status := http.StatusCreated
+	if affected == 0 {
+		// Already attached: no event, no-op response instead of a
+		// duplicate "tagged" entry in the audit timeline.
+		status = http.StatusOK
+	} else if err := events.Record(ctx, tx, id, events.Tagged, tag); err != nil {
+		respondInternalError(w, r, err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondInternalError(w, r, err)
+		return
+	}
+
+	respondData(w, status, tag)
+}
+
+// removeBookTag handles DELETE /books/{id}/tags/{tagID}.
+func (s *server) removeBookTag(w http.ResponseWriter, r *http.Request) {
+	id, err := bookID(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+	tagID, err := strconv.ParseInt(chi.URLParam(r, "tagID"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid tag id")
+		return
+	}
+
+	ctx := r.Context()
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		respondInternalError(w, r, err)
+		return
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM book_tags WHERE book_id = $1 AND tag_id = $2`, id, tagID)
+	if err != nil {
+		respondInternalError(w, r, err)
+		return
+	}
+	affected, _ := res.RowsAffected()
+	if affected == 0 {
+		respondError(w, http.StatusNotFound, "tag not attached to book")
+		return
+	}
+
+	if err := events.Record(ctx, tx, id, events.Untagged, map[string]int64{"tag_id": tagID}); err != nil {
+		respondInternalError(w, r, err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondInternalError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
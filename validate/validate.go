@@ -0,0 +1,52 @@
+// Package validate provides small, composable field-level validation
+// rules and the structured error type used to report them.
+package validate
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Errors collects field-level validation failures, keyed by field name.
+// It implements error so it can be returned and handled like any other
+// error, while still letting a caller recover the per-field detail with
+// a type assertion.
+type Errors map[string]string
+
+func (e Errors) Error() string {
+	var b strings.Builder
+	for field, msg := range e {
+		if b.Len() > 0 {
+			b.WriteString("; ")
+		}
+		fmt.Fprintf(&b, "%s: %s", field, msg)
+	}
+	return b.String()
+}
+
+// Validator is implemented by payload types that can check their own
+// fields, collecting any failures into Errors.
+type Validator interface {
+	Validate() Errors
+}
+
+// String adds an entry to errs under field if value isn't valid UTF-8,
+// contains a control character, or its rune count falls outside
+// [min, max]. value is expected to already be trimmed by the caller.
+func String(errs Errors, field, value string, min, max int) {
+	if !utf8.ValidString(value) {
+		errs[field] = "must be valid UTF-8"
+		return
+	}
+	for _, r := range value {
+		if unicode.IsControl(r) {
+			errs[field] = "must not contain control characters"
+			return
+		}
+	}
+	if n := utf8.RuneCountInString(value); n < min || n > max {
+		errs[field] = fmt.Sprintf("must be %d-%d chars", min, max)
+	}
+}
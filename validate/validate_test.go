@@ -0,0 +1,49 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStringLengthBounds(t *testing.T) {
+	errs := Errors{}
+	String(errs, "title", "", 1, 255)
+	if _, ok := errs["title"]; !ok {
+		t.Error("expected an error for a blank title")
+	}
+
+	errs = Errors{}
+	String(errs, "title", "Dune", 1, 255)
+	if len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+
+	errs = Errors{}
+	String(errs, "title", strings.Repeat("a", 256), 1, 255)
+	if _, ok := errs["title"]; !ok {
+		t.Error("expected an error for a too-long title")
+	}
+}
+
+func TestStringRejectsControlChars(t *testing.T) {
+	errs := Errors{}
+	String(errs, "title", "bad\ntitle", 1, 255)
+	if _, ok := errs["title"]; !ok {
+		t.Error("expected an error for a control character")
+	}
+}
+
+func TestStringRejectsInvalidUTF8(t *testing.T) {
+	errs := Errors{}
+	String(errs, "title", string([]byte{0xff, 0xfe}), 1, 255)
+	if _, ok := errs["title"]; !ok {
+		t.Error("expected an error for invalid UTF-8")
+	}
+}
+
+func TestErrorsError(t *testing.T) {
+	errs := Errors{"title": "must be 1-255 chars"}
+	if got := errs.Error(); got != "title: must be 1-255 chars" {
+		t.Errorf("Error() = %q, want %q", got, "title: must be 1-255 chars")
+	}
+}